@@ -1,6 +1,7 @@
 package main
 
 import (
+    "encoding/json"
     "fmt"
     "log"
     "net/http"
@@ -8,20 +9,81 @@ import (
     "os/signal"
     "syscall"
     "time"
+
+    mcp "github.com/Consiliency/mcp-platform/sdk/go"
 )
 
+const defaultTenantID = "default"
+
 func healthHandler(w http.ResponseWriter, r *http.Request) {
     w.WriteHeader(http.StatusOK)
     fmt.Fprintf(w, `{"status":"healthy","timestamp":"%s"}`, time.Now().Format(time.RFC3339))
 }
 
+// servicesHandler lists the services visible to the tenant named in the
+// X-Tenant-ID header, lazily authenticating a Client for that tenant on
+// first use.
+func servicesHandler(pool *mcp.TenantPool) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        tenantID := r.Header.Get("X-Tenant-ID")
+        if tenantID == "" {
+            tenantID = defaultTenantID
+        }
+
+        if !pool.Allow(tenantID) {
+            w.WriteHeader(http.StatusTooManyRequests)
+            fmt.Fprintf(w, `{"error":"rate limit exceeded for tenant %s"}`, tenantID)
+            return
+        }
+
+        client, err := pool.For(r.Context(), tenantID)
+        if err != nil {
+            pool.RecordError(tenantID)
+            w.WriteHeader(http.StatusBadGateway)
+            fmt.Fprintf(w, `{"error":"%s"}`, err)
+            return
+        }
+        pool.RecordRequest(tenantID)
+
+        services, err := client.ListServices(r.Context(), nil)
+        if err != nil {
+            pool.RecordError(tenantID)
+            w.WriteHeader(http.StatusBadGateway)
+            fmt.Fprintf(w, `{"error":"%s"}`, err)
+            return
+        }
+
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(services)
+    }
+}
+
+func newTenantPool() *mcp.TenantPool {
+    apiKey := os.Getenv("MCP_API_KEY")
+    authFactory := func(tenantID string) mcp.AuthProvider {
+        return &mcp.APIKeyAuthProvider{APIKey: apiKey}
+    }
+
+    return mcp.NewTenantPool(mcp.TenantPoolConfig{
+        BaseConfig: mcp.Config{
+            BaseURL: os.Getenv("MCP_BASE_URL"),
+        },
+        AuthProviderFactory: authFactory,
+        RateLimitPerSecond:  50,
+        MetricsVarName:      "mcp_tenant_pool",
+    })
+}
+
 func main() {
     port := os.Getenv("PORT")
     if port == "" {
         port = "8080"
     }
 
+    pool := newTenantPool()
+
     http.HandleFunc("/health", healthHandler)
+    http.HandleFunc("/services", servicesHandler(pool))
     http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
         fmt.Fprintf(w, "MCP Worker Service v1.0.0")
     })
@@ -43,4 +105,4 @@ func main() {
 
     log.Println("Shutting down server...")
     server.Close()
-}
\ No newline at end of file
+}
@@ -0,0 +1,167 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AuthProvider authenticates against the MCP platform and returns a token
+// that the client attaches to subsequent requests. Implementations are
+// expected to be safe for concurrent use, since the client may invoke
+// Authenticate again from the background renewer while a request is
+// in flight.
+type AuthProvider interface {
+	Authenticate(ctx context.Context, httpClient *http.Client, baseURL string) (*AuthResult, error)
+}
+
+// APIKeyAuthProvider authenticates using a static API key exchanged for a
+// short-lived token via the platform's /auth/apikey endpoint.
+type APIKeyAuthProvider struct {
+	APIKey string
+}
+
+// Authenticate implements AuthProvider.
+func (p *APIKeyAuthProvider) Authenticate(ctx context.Context, httpClient *http.Client, baseURL string) (*AuthResult, error) {
+	if p.APIKey == "" {
+		return nil, errAuth("api key must not be empty")
+	}
+
+	body, err := json.Marshal(map[string]string{"api_key": p.APIKey})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/auth/apikey", strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return doAuthRequest(httpClient, req)
+}
+
+// OAuth2ClientCredentialsAuthProvider authenticates using the OAuth2
+// client-credentials grant against TokenURL.
+type OAuth2ClientCredentialsAuthProvider struct {
+	ClientID     string
+	ClientSecret string
+	TokenURL     string
+	Scopes       []string
+}
+
+// Authenticate implements AuthProvider.
+func (p *OAuth2ClientCredentialsAuthProvider) Authenticate(ctx context.Context, httpClient *http.Client, baseURL string) (*AuthResult, error) {
+	if p.ClientID == "" || p.ClientSecret == "" {
+		return nil, errAuth("client id and client secret are required")
+	}
+
+	tokenURL := p.TokenURL
+	if tokenURL == "" {
+		tokenURL = baseURL + "/oauth2/token"
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", p.ClientID)
+	form.Set("client_secret", p.ClientSecret)
+	if len(p.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return doAuthRequest(httpClient, req)
+}
+
+// JWTBearerAuthProvider authenticates by presenting a pre-signed JWT to the
+// platform's token exchange endpoint, receiving a platform session token
+// in return.
+type JWTBearerAuthProvider struct {
+	Assertion string
+}
+
+// Authenticate implements AuthProvider.
+func (p *JWTBearerAuthProvider) Authenticate(ctx context.Context, httpClient *http.Client, baseURL string) (*AuthResult, error) {
+	if p.Assertion == "" {
+		return nil, errAuth("jwt assertion must not be empty")
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", p.Assertion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return doAuthRequest(httpClient, req)
+}
+
+// authResponse mirrors the JSON body returned by the platform's token
+// endpoints, expressed either as expires_at or expires_in seconds.
+type authResponse struct {
+	Token       string    `json:"token"`
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	ExpiresIn   int64     `json:"expires_in"`
+
+	// StreamTransport is the transport the server picked for this session's
+	// ServiceProxy.Stream calls (one of the streamTransport* constants).
+	// Absent on servers that predate streaming support.
+	StreamTransport string `json:"stream_transport"`
+}
+
+func doAuthRequest(httpClient *http.Client, req *http.Request) (*AuthResult, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, errFromResponse(resp.StatusCode, body, resp.Header)
+	}
+
+	var parsed authResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, &Error{Code: CodeInternal, Message: "decoding auth response", Cause: err}
+	}
+
+	token := parsed.Token
+	if token == "" {
+		token = parsed.AccessToken
+	}
+	if token == "" {
+		return nil, errAuth("auth response did not contain a token")
+	}
+
+	expiresAt := parsed.ExpiresAt
+	if expiresAt.IsZero() && parsed.ExpiresIn > 0 {
+		expiresAt = time.Now().Add(time.Duration(parsed.ExpiresIn) * time.Second)
+	}
+	if expiresAt.IsZero() {
+		expiresAt = time.Now().Add(time.Hour)
+	}
+
+	return &AuthResult{
+		Token:           token,
+		ExpiresAt:       expiresAt,
+		StreamTransport: parsed.StreamTransport,
+	}, nil
+}
+
+func errAuth(msg string) error {
+	return &Error{Code: CodeUnauthenticated, Message: msg}
+}
@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestAPIKeyAuthProviderAuthenticates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/auth/apikey" {
+			t.Errorf("path = %q, want /auth/apikey", r.URL.Path)
+		}
+		var body struct {
+			APIKey string `json:"api_key"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.APIKey != "secret" {
+			t.Errorf("api_key = %q, want secret", body.APIKey)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"token": "tok-1", "expires_in": 3600})
+	}))
+	defer srv.Close()
+
+	provider := &APIKeyAuthProvider{APIKey: "secret"}
+	result, err := provider.Authenticate(context.Background(), srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if result.Token != "tok-1" {
+		t.Fatalf("Token = %q, want tok-1", result.Token)
+	}
+	if result.ExpiresAt.IsZero() {
+		t.Fatal("ExpiresAt should be derived from expires_in")
+	}
+}
+
+func TestAPIKeyAuthProviderRejectsEmptyKey(t *testing.T) {
+	provider := &APIKeyAuthProvider{}
+	if _, err := provider.Authenticate(context.Background(), http.DefaultClient, "http://unused"); !IsCode(err, CodeUnauthenticated) {
+		t.Fatalf("err = %v, want CodeUnauthenticated", err)
+	}
+}
+
+func TestOAuth2ClientCredentialsAuthProviderAuthenticates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+		if form.Get("grant_type") != "client_credentials" {
+			t.Errorf("grant_type = %q, want client_credentials", form.Get("grant_type"))
+		}
+		if form.Get("client_id") != "id" || form.Get("client_secret") != "sec" {
+			t.Errorf("unexpected client credentials in form: %v", form)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "tok-2", "expires_in": 60})
+	}))
+	defer srv.Close()
+
+	provider := &OAuth2ClientCredentialsAuthProvider{ClientID: "id", ClientSecret: "sec", TokenURL: srv.URL}
+	result, err := provider.Authenticate(context.Background(), srv.Client(), "http://unused")
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if result.Token != "tok-2" {
+		t.Fatalf("Token = %q, want tok-2", result.Token)
+	}
+}
+
+func TestOAuth2ClientCredentialsAuthProviderRejectsMissingFields(t *testing.T) {
+	provider := &OAuth2ClientCredentialsAuthProvider{ClientID: "id"}
+	if _, err := provider.Authenticate(context.Background(), http.DefaultClient, "http://unused"); !IsCode(err, CodeUnauthenticated) {
+		t.Fatalf("err = %v, want CodeUnauthenticated", err)
+	}
+}
+
+func TestJWTBearerAuthProviderAuthenticates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+		if form.Get("assertion") != "jwt-assertion" {
+			t.Errorf("assertion = %q, want jwt-assertion", form.Get("assertion"))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"token": "tok-3", "expires_in": 60, "stream_transport": "chunked"})
+	}))
+	defer srv.Close()
+
+	provider := &JWTBearerAuthProvider{Assertion: "jwt-assertion"}
+	result, err := provider.Authenticate(context.Background(), srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if result.Token != "tok-3" {
+		t.Fatalf("Token = %q, want tok-3", result.Token)
+	}
+	if result.StreamTransport != "chunked" {
+		t.Fatalf("StreamTransport = %q, want chunked", result.StreamTransport)
+	}
+}
+
+func TestJWTBearerAuthProviderRejectsEmptyAssertion(t *testing.T) {
+	provider := &JWTBearerAuthProvider{}
+	if _, err := provider.Authenticate(context.Background(), http.DefaultClient, "http://unused"); !IsCode(err, CodeUnauthenticated) {
+		t.Fatalf("err = %v, want CodeUnauthenticated", err)
+	}
+}
+
+func TestDoAuthRequestSurfacesErrorResponses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-1")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"message": "bad credentials"})
+	}))
+	defer srv.Close()
+
+	provider := &APIKeyAuthProvider{APIKey: "wrong"}
+	_, err := provider.Authenticate(context.Background(), srv.Client(), srv.URL)
+	if !IsCode(err, CodeUnauthenticated) {
+		t.Fatalf("err = %v, want CodeUnauthenticated", err)
+	}
+}
@@ -0,0 +1,283 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CatalogEventType classifies how a service changed between two catalog
+// snapshots.
+type CatalogEventType int
+
+const (
+	CatalogAdded CatalogEventType = iota
+	CatalogUpdated
+	CatalogRemoved
+)
+
+func (t CatalogEventType) String() string {
+	switch t {
+	case CatalogAdded:
+		return "added"
+	case CatalogUpdated:
+		return "updated"
+	case CatalogRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// CatalogEvent describes a single service that changed during a catalog
+// refresh.
+type CatalogEvent struct {
+	Type    CatalogEventType
+	Service Service
+}
+
+// ServiceCatalog caches the platform's service list for TTL, refreshing it
+// in the background and diffing successive snapshots (by hashing each
+// service, consul-watcher style) to derive Added/Updated/Removed events for
+// subscribers. Client.ListServices and Client.GetService consult it
+// transparently instead of hitting the API on every call.
+type ServiceCatalog struct {
+	ttl     time.Duration
+	fetch   func(ctx context.Context) ([]Service, error)
+	startBG sync.Once
+
+	mu        sync.RWMutex
+	services  map[string]Service
+	hashes    map[string]string
+	fetchedAt time.Time
+	watchers  map[chan CatalogEvent]struct{}
+}
+
+// newServiceCatalog constructs a catalog that refreshes via fetch, caching
+// results for ttl.
+func newServiceCatalog(ttl time.Duration, fetch func(ctx context.Context) ([]Service, error)) *ServiceCatalog {
+	return &ServiceCatalog{
+		ttl:      ttl,
+		fetch:    fetch,
+		services: make(map[string]Service),
+		hashes:   make(map[string]string),
+		watchers: make(map[chan CatalogEvent]struct{}),
+	}
+}
+
+// List returns the cached services, refreshing first if the cache is
+// stale or has never been populated.
+func (cat *ServiceCatalog) List(ctx context.Context) ([]Service, error) {
+	if cat.stale() {
+		if err := cat.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	cat.mu.RLock()
+	defer cat.mu.RUnlock()
+	services := make([]Service, 0, len(cat.services))
+	for _, svc := range cat.services {
+		services = append(services, svc)
+	}
+	return services, nil
+}
+
+// Get returns a single cached service by ID, refreshing first if the cache
+// is stale. ok is false if no such service exists even after a refresh.
+func (cat *ServiceCatalog) Get(ctx context.Context, serviceID string) (svc Service, ok bool, err error) {
+	if cat.stale() {
+		if err := cat.refresh(ctx); err != nil {
+			return Service{}, false, err
+		}
+	}
+
+	cat.mu.RLock()
+	defer cat.mu.RUnlock()
+	svc, ok = cat.services[serviceID]
+	return svc, ok, nil
+}
+
+// Upsert updates the cached entry for svc without waiting for the next
+// background refresh, emitting an Added or Updated event as appropriate.
+// It's used to keep the cache coherent after an install/uninstall.
+func (cat *ServiceCatalog) Upsert(svc Service) {
+	hash := hashService(svc)
+
+	cat.mu.Lock()
+	_, existed := cat.services[svc.ID]
+	cat.services[svc.ID] = svc
+	changed := cat.hashes[svc.ID] != hash
+	cat.hashes[svc.ID] = hash
+	cat.mu.Unlock()
+
+	if !existed {
+		cat.notify(CatalogEvent{Type: CatalogAdded, Service: svc})
+	} else if changed {
+		cat.notify(CatalogEvent{Type: CatalogUpdated, Service: svc})
+	}
+}
+
+// Watch registers a subscriber for catalog change events and starts the
+// catalog's background refresh loop if it isn't already running. It
+// immediately delivers the current snapshot to the new subscriber as
+// Added events, refreshing first if the cache is stale or has never been
+// populated, so a Watch-only caller doesn't wait for the first TTL tick to
+// see anything. The returned channel is closed when ctx is done.
+func (cat *ServiceCatalog) Watch(ctx context.Context) (<-chan CatalogEvent, error) {
+	if cat.stale() {
+		if err := cat.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	cat.mu.Lock()
+	ch := make(chan CatalogEvent, len(cat.services)+16)
+	cat.watchers[ch] = struct{}{}
+	for _, svc := range cat.services {
+		select {
+		case ch <- CatalogEvent{Type: CatalogAdded, Service: svc}:
+		default:
+		}
+	}
+	cat.mu.Unlock()
+
+	cat.startBG.Do(func() {
+		go cat.refreshLoop()
+	})
+
+	go func() {
+		<-ctx.Done()
+		cat.mu.Lock()
+		delete(cat.watchers, ch)
+		cat.mu.Unlock()
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+// stale reports whether the cache needs refreshing, either because it has
+// never been populated or because ttl has elapsed.
+func (cat *ServiceCatalog) stale() bool {
+	cat.mu.RLock()
+	defer cat.mu.RUnlock()
+	return cat.fetchedAt.IsZero() || time.Since(cat.fetchedAt) >= cat.ttl
+}
+
+// refresh fetches a fresh snapshot and diffs it against the cache,
+// notifying watchers of anything that changed.
+func (cat *ServiceCatalog) refresh(ctx context.Context) error {
+	fetched, err := cat.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	fresh := make(map[string]Service, len(fetched))
+	freshHashes := make(map[string]string, len(fetched))
+	for _, svc := range fetched {
+		fresh[svc.ID] = svc
+		freshHashes[svc.ID] = hashService(svc)
+	}
+
+	cat.mu.Lock()
+	var events []CatalogEvent
+	for id, svc := range fresh {
+		if oldHash, existed := cat.hashes[id]; !existed {
+			events = append(events, CatalogEvent{Type: CatalogAdded, Service: svc})
+		} else if oldHash != freshHashes[id] {
+			events = append(events, CatalogEvent{Type: CatalogUpdated, Service: svc})
+		}
+	}
+	for id, svc := range cat.services {
+		if _, stillPresent := fresh[id]; !stillPresent {
+			events = append(events, CatalogEvent{Type: CatalogRemoved, Service: svc})
+		}
+	}
+	cat.services = fresh
+	cat.hashes = freshHashes
+	cat.fetchedAt = time.Now()
+	cat.mu.Unlock()
+
+	for _, ev := range events {
+		cat.notify(ev)
+	}
+	return nil
+}
+
+// refreshLoop keeps the cache warm in the background for as long as there
+// is at least one watcher, so Watch subscribers see changes without
+// anyone having to call List.
+func (cat *ServiceCatalog) refreshLoop() {
+	ticker := time.NewTicker(cat.ttl)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		cat.mu.RLock()
+		hasWatchers := len(cat.watchers) > 0
+		cat.mu.RUnlock()
+		if !hasWatchers {
+			continue
+		}
+		_ = cat.refresh(context.Background())
+	}
+}
+
+func (cat *ServiceCatalog) notify(ev CatalogEvent) {
+	cat.mu.RLock()
+	defer cat.mu.RUnlock()
+	for ch := range cat.watchers {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// hashService derives a stable fingerprint for a service so refresh can
+// detect whether it changed between snapshots without a field-by-field
+// comparison.
+func hashService(svc Service) string {
+	encoded, err := json.Marshal(svc)
+	if err != nil {
+		return fmt.Sprintf("err:%v", err)
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// filterServices applies the category/tag/installed filters supported by
+// Client.ListServices against an already-fetched snapshot.
+func filterServices(services []Service, filters map[string]interface{}) []Service {
+	if len(filters) == 0 {
+		return services
+	}
+
+	filtered := services[:0:0]
+	for _, svc := range services {
+		if category, ok := filters["category"].(string); ok && category != "" && svc.Category != category {
+			continue
+		}
+		if tag, ok := filters["tag"].(string); ok && tag != "" && !containsString(svc.Tags, tag) {
+			continue
+		}
+		if installed, ok := filters["installed"].(bool); ok && svc.Installed != installed {
+			continue
+		}
+		filtered = append(filtered, svc)
+	}
+	return filtered
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package mcp
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCatalogWatchEmitsDiffEvents(t *testing.T) {
+	var step int32
+	fetch := func(ctx context.Context) ([]Service, error) {
+		switch atomic.LoadInt32(&step) {
+		case 0:
+			return []Service{{ID: "a", Name: "A", Version: "1"}}, nil
+		case 1:
+			return []Service{{ID: "a", Name: "A", Version: "2"}, {ID: "b", Name: "B", Version: "1"}}, nil
+		default:
+			return []Service{{ID: "a", Name: "A", Version: "2"}}, nil
+		}
+	}
+
+	cat := newServiceCatalog(10*time.Millisecond, fetch)
+	if err := cat.refresh(context.Background()); err != nil {
+		t.Fatalf("initial refresh: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := cat.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	atomic.StoreInt32(&step, 1)
+	// refresh emits its events in map order, so accept Updated a and
+	// Added b in either order.
+	var updated, added bool
+	deadline := time.After(2 * time.Second)
+	for !updated || !added {
+		select {
+		case ev := <-ch:
+			updated = updated || (ev.Type == CatalogUpdated && ev.Service.ID == "a")
+			added = added || (ev.Type == CatalogAdded && ev.Service.ID == "b")
+		case <-deadline:
+			t.Fatalf("updated=%v added=%v, want both true", updated, added)
+		}
+	}
+
+	atomic.StoreInt32(&step, 2)
+	if !waitForEvent(t, ch, CatalogRemoved, "b") {
+		t.Fatal("expected a Removed event for b")
+	}
+}
+
+// waitForEvent drains ch until it sees an event matching (typ, serviceID)
+// or the deadline passes.
+func waitForEvent(t *testing.T, ch <-chan CatalogEvent, typ CatalogEventType, serviceID string) bool {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case ev := <-ch:
+			if ev.Type == typ && ev.Service.ID == serviceID {
+				return true
+			}
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+func TestWatchDeliversInitialSnapshotWithoutWaitingForTick(t *testing.T) {
+	fetch := func(ctx context.Context) ([]Service, error) {
+		return []Service{{ID: "a", Name: "A", Version: "1"}}, nil
+	}
+
+	cat := newServiceCatalog(200*time.Millisecond, fetch)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := cat.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	select {
+	case ev := <-ch:
+		if ev.Type != CatalogAdded || ev.Service.ID != "a" {
+			t.Fatalf("got event %+v, want an Added event for service a", ev)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Watch-only subscriber got no event before the first TTL tick")
+	}
+}
+
+func TestCatalogListCachesWithinTTL(t *testing.T) {
+	var calls int32
+	fetch := func(ctx context.Context) ([]Service, error) {
+		atomic.AddInt32(&calls, 1)
+		return []Service{{ID: "a"}}, nil
+	}
+
+	cat := newServiceCatalog(time.Hour, fetch)
+
+	if _, err := cat.List(context.Background()); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if _, err := cat.List(context.Background()); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("fetch called %d times, want 1 (second List should hit the cache)", got)
+	}
+}
+
+func TestFilterServices(t *testing.T) {
+	services := []Service{
+		{ID: "a", Category: "database", Tags: []string{"sql"}, Installed: true},
+		{ID: "b", Category: "database", Tags: []string{"nosql"}, Installed: false},
+		{ID: "c", Category: "search", Tags: []string{"sql"}, Installed: true},
+	}
+
+	got := filterServices(services, map[string]interface{}{"category": "database"})
+	if len(got) != 2 {
+		t.Fatalf("category filter: got %d services, want 2", len(got))
+	}
+
+	got = filterServices(services, map[string]interface{}{"tag": "sql"})
+	if len(got) != 2 {
+		t.Fatalf("tag filter: got %d services, want 2", len(got))
+	}
+
+	got = filterServices(services, map[string]interface{}{"installed": true})
+	if len(got) != 2 {
+		t.Fatalf("installed filter: got %d services, want 2", len(got))
+	}
+
+	got = filterServices(services, map[string]interface{}{"category": "database", "installed": true})
+	if len(got) != 1 || got[0].ID != "a" {
+		t.Fatalf("combined filter: got %+v, want only service a", got)
+	}
+}
@@ -0,0 +1,202 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Code classifies the kind of failure behind an Error, independent of the
+// transport that carried it, so callers can branch on failure kind instead
+// of matching against error strings.
+type Code int
+
+const (
+	// CodeUnknown is the zero value; it indicates a failure that could not
+	// be classified, such as a method-level error from ServiceProxy.Call
+	// whose code is not one of the names below.
+	CodeUnknown Code = iota
+	CodeValidationFailed
+	CodeInternal
+	CodeNoPermission
+	CodeNotFound
+	CodeAlreadyExists
+	CodeConflict
+	CodeUnimplemented
+	CodeBadInput
+	CodeUnauthenticated
+	CodeDeadlineExceeded
+	CodeExternal
+)
+
+// String returns the canonical name for c, also used as the wire
+// representation of method-level errors returned by ServiceProxy.Call.
+func (c Code) String() string {
+	switch c {
+	case CodeValidationFailed:
+		return "ValidationFailed"
+	case CodeInternal:
+		return "Internal"
+	case CodeNoPermission:
+		return "NoPermission"
+	case CodeNotFound:
+		return "NotFound"
+	case CodeAlreadyExists:
+		return "AlreadyExists"
+	case CodeConflict:
+		return "Conflict"
+	case CodeUnimplemented:
+		return "Unimplemented"
+	case CodeBadInput:
+		return "BadInput"
+	case CodeUnauthenticated:
+		return "Unauthenticated"
+	case CodeDeadlineExceeded:
+		return "DeadlineExceeded"
+	case CodeExternal:
+		return "External"
+	default:
+		return "Unknown"
+	}
+}
+
+// codeFromString maps the wire representation of a Code (as sent by the
+// platform API or another MCP service) back to its typed value.
+func codeFromString(s string) Code {
+	for code := CodeValidationFailed; code <= CodeExternal; code++ {
+		if code.String() == s {
+			return code
+		}
+	}
+	return CodeUnknown
+}
+
+// Error is the typed error returned throughout this package in place of
+// ad-hoc errors.New strings, so that Go consumers can use errors.Is/As (or
+// the IsCode/As helpers below) instead of string matching.
+type Error struct {
+	Code       Code
+	Message    string
+	Cause      error
+	RequestID  string
+	HTTPStatus int
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("mcp: %s: %s", e.Code, e.Message)
+	if e.RequestID != "" {
+		msg = fmt.Sprintf("%s (request_id=%s)", msg, e.RequestID)
+	}
+	if e.Cause != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.Cause)
+	}
+	return msg
+}
+
+// Unwrap allows errors.Is/errors.As to see through to the underlying cause.
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is an *Error with the same Code, so that
+// errors.Is(err, ErrNotFound) works without comparing messages or causes.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+// Sentinel errors for the most common failure kinds, usable directly with
+// errors.Is.
+var (
+	ErrValidationFailed = &Error{Code: CodeValidationFailed, Message: "validation failed"}
+	ErrInternal         = &Error{Code: CodeInternal, Message: "internal error"}
+	ErrNoPermission     = &Error{Code: CodeNoPermission, Message: "permission denied"}
+	ErrNotFound         = &Error{Code: CodeNotFound, Message: "not found"}
+	ErrAlreadyExists    = &Error{Code: CodeAlreadyExists, Message: "already exists"}
+	ErrConflict         = &Error{Code: CodeConflict, Message: "conflict"}
+	ErrUnimplemented    = &Error{Code: CodeUnimplemented, Message: "not implemented"}
+	ErrBadInput         = &Error{Code: CodeBadInput, Message: "bad input"}
+	ErrUnauthenticated  = &Error{Code: CodeUnauthenticated, Message: "authentication required"}
+	ErrDeadlineExceeded = &Error{Code: CodeDeadlineExceeded, Message: "deadline exceeded"}
+	ErrExternal         = &Error{Code: CodeExternal, Message: "external error"}
+)
+
+// IsCode reports whether err is (or wraps) an *Error with the given Code.
+func IsCode(err error, code Code) bool {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code == code
+	}
+	return false
+}
+
+// As extracts the *Error wrapped by err into target, returning false if err
+// does not carry one. Unlike errors.As, target is the Error value itself
+// rather than a pointer to an error-typed variable.
+func As(err error, target *Error) bool {
+	var e *Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	*target = *e
+	return true
+}
+
+// errFromResponse builds a typed Error describing a non-2xx API response,
+// classifying it by HTTP status and pulling out the request ID the
+// platform attaches to every response for support/debugging purposes.
+func errFromResponse(status int, body []byte, header http.Header) error {
+	var parsed struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	_ = json.Unmarshal(body, &parsed)
+
+	message := parsed.Message
+	if message == "" {
+		message = parsed.Error
+	}
+	if message == "" {
+		message = fmt.Sprintf("request failed with status %d", status)
+	}
+
+	return &Error{
+		Code:       codeFromHTTPStatus(status),
+		Message:    message,
+		RequestID:  header.Get("X-Request-ID"),
+		HTTPStatus: status,
+	}
+}
+
+// codeFromHTTPStatus maps an HTTP status code from the platform API to the
+// Code it most closely represents.
+func codeFromHTTPStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadInput
+	case http.StatusUnauthorized:
+		return CodeUnauthenticated
+	case http.StatusForbidden:
+		return CodeNoPermission
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusUnprocessableEntity:
+		return CodeValidationFailed
+	case http.StatusNotImplemented:
+		return CodeUnimplemented
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return CodeDeadlineExceeded
+	default:
+		if status >= 500 {
+			return CodeInternal
+		}
+		return CodeExternal
+	}
+}
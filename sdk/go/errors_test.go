@@ -0,0 +1,98 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCodeFromHTTPStatus(t *testing.T) {
+	cases := []struct {
+		status int
+		want   Code
+	}{
+		{http.StatusBadRequest, CodeBadInput},
+		{http.StatusUnauthorized, CodeUnauthenticated},
+		{http.StatusForbidden, CodeNoPermission},
+		{http.StatusNotFound, CodeNotFound},
+		{http.StatusConflict, CodeConflict},
+		{http.StatusUnprocessableEntity, CodeValidationFailed},
+		{http.StatusNotImplemented, CodeUnimplemented},
+		{http.StatusRequestTimeout, CodeDeadlineExceeded},
+		{http.StatusGatewayTimeout, CodeDeadlineExceeded},
+		{http.StatusInternalServerError, CodeInternal},
+		{http.StatusBadGateway, CodeInternal},
+		{http.StatusTeapot, CodeExternal},
+	}
+
+	for _, c := range cases {
+		if got := codeFromHTTPStatus(c.status); got != c.want {
+			t.Errorf("codeFromHTTPStatus(%d) = %v, want %v", c.status, got, c.want)
+		}
+	}
+}
+
+func TestErrFromResponse(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Request-ID", "req-123")
+
+	err := errFromResponse(http.StatusNotFound, []byte(`{"message":"no such service"}`), header)
+
+	if !IsCode(err, CodeNotFound) {
+		t.Fatalf("err code = %v, want CodeNotFound", err)
+	}
+
+	var target Error
+	if !As(err, &target) {
+		t.Fatalf("As failed to extract *Error from %v", err)
+	}
+	if target.Message != "no such service" {
+		t.Fatalf("Message = %q, want %q", target.Message, "no such service")
+	}
+	if target.RequestID != "req-123" {
+		t.Fatalf("RequestID = %q, want req-123", target.RequestID)
+	}
+	if target.HTTPStatus != http.StatusNotFound {
+		t.Fatalf("HTTPStatus = %d, want 404", target.HTTPStatus)
+	}
+}
+
+func TestErrFromResponseFallsBackToErrorField(t *testing.T) {
+	err := errFromResponse(http.StatusBadRequest, []byte(`{"error":"invalid params"}`), http.Header{})
+	var target Error
+	if !As(err, &target) {
+		t.Fatalf("As failed to extract *Error from %v", err)
+	}
+	if target.Message != "invalid params" {
+		t.Fatalf("Message = %q, want %q", target.Message, "invalid params")
+	}
+}
+
+func TestErrFromResponseDefaultMessage(t *testing.T) {
+	err := errFromResponse(http.StatusInternalServerError, []byte(`not json`), http.Header{})
+	var target Error
+	if !As(err, &target) {
+		t.Fatalf("As failed to extract *Error from %v", err)
+	}
+	if target.Message == "" {
+		t.Fatal("expected a non-empty default message for an unparseable body")
+	}
+}
+
+func TestIsCodeAndAs(t *testing.T) {
+	err := &Error{Code: CodeConflict, Message: "already running"}
+
+	if !IsCode(err, CodeConflict) {
+		t.Fatal("IsCode should match the error's Code")
+	}
+	if IsCode(err, CodeNotFound) {
+		t.Fatal("IsCode should not match an unrelated Code")
+	}
+
+	var target Error
+	if !As(err, &target) {
+		t.Fatal("As should extract the *Error")
+	}
+	if target.Message != "already running" {
+		t.Fatalf("Message = %q, want %q", target.Message, "already running")
+	}
+}
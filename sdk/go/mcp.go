@@ -3,9 +3,6 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
-	"fmt"
 	"net/http"
 	"sync"
 	"time"
@@ -18,34 +15,79 @@ type Config struct {
 	Timeout       time.Duration
 	RetryAttempts int
 	TenantID      string
+
+	// AuthProvider, when set, is used to obtain and refresh the token used
+	// to authenticate requests. If nil and APIKey is set, Connect falls
+	// back to an APIKeyAuthProvider built from APIKey for convenience.
+	AuthProvider AuthProvider
+
+	// RenewBehavior controls how the background token renewer reacts to
+	// renewal failures. Defaults to RenewIgnoreErrors.
+	RenewBehavior RenewBehavior
+
+	// Proxy, when set, routes all ServiceProxy.Call invocations through
+	// this gateway address instead of the client's BaseURL.
+	// Useful when MCP services sit behind a shared ingress or service
+	// mesh sidecar. Individual calls may override or bypass it with the
+	// WithProxy / WithoutProxy CallOptions.
+	Proxy string
+
+	// TenantProxies maps a tenant ID to a proxy address, overriding Proxy
+	// for calls made with that TenantID. Consulted before falling back
+	// to Proxy.
+	TenantProxies map[string]string
+
+	// CatalogTTL controls how long ListServices/GetService results are
+	// cached before the next call triggers a refresh. Defaults to 30s.
+	CatalogTTL time.Duration
 }
 
 // Client is the main MCP client
 type Client struct {
-	config       Config
-	authToken    string
-	authExpiry   time.Time
-	services     map[string]*ServiceProxy
-	httpClient   *http.Client
-	mu           sync.RWMutex
+	config     Config
+	authToken  string
+	authExpiry time.Time
+	services   map[string]*ServiceProxy
+	httpClient *http.Client
+	mu         sync.RWMutex
+
+	// Background renewal state. renewCond is signalled whenever renewing
+	// flips back to false, letting checkAuth wait out an in-flight
+	// renewal instead of racing it.
+	renewCancel   context.CancelFunc
+	renewWG       sync.WaitGroup
+	renewCond     *sync.Cond
+	renewing      bool
+	renewBehavior RenewBehavior
+	lastRenewErr  error
+
+	catalog *ServiceCatalog
+
+	// streamTransport is the transport negotiated at Connect time for
+	// ServiceProxy.Stream; see the streamTransport* constants in stream.go.
+	streamTransport string
 }
 
 // Service represents an MCP service
 type Service struct {
-	ID           string   `json:"id"`
-	Name         string   `json:"name"`
-	Description  string   `json:"description"`
-	Version      string   `json:"version"`
-	Category     string   `json:"category"`
-	Tags         []string `json:"tags"`
-	Status       string   `json:"status"`
-	Installed    bool     `json:"installed"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Version     string   `json:"version"`
+	Category    string   `json:"category"`
+	Tags        []string `json:"tags"`
+	Status      string   `json:"status"`
+	Installed   bool     `json:"installed"`
 }
 
 // AuthResult represents authentication result
 type AuthResult struct {
 	Token     string    `json:"token"`
 	ExpiresAt time.Time `json:"expires_at"`
+
+	// StreamTransport is the transport negotiated for this session's
+	// ServiceProxy.Stream calls; see the streamTransport* constants.
+	StreamTransport string `json:"stream_transport"`
 }
 
 // InstallResult represents service installation result
@@ -71,44 +113,74 @@ func NewClient(config Config) *Client {
 	if config.RetryAttempts == 0 {
 		config.RetryAttempts = 3
 	}
+	if config.CatalogTTL == 0 {
+		config.CatalogTTL = 30 * time.Second
+	}
 
-	return &Client{
-		config: config,
+	c := &Client{
+		config:   config,
 		services: make(map[string]*ServiceProxy),
 		httpClient: &http.Client{
 			Timeout: config.Timeout,
 		},
 	}
+	c.renewCond = sync.NewCond(&c.mu)
+	c.catalog = newServiceCatalog(config.CatalogTTL, c.fetchServices)
+	return c
 }
 
-// Connect authenticates with MCP using the provided credentials
+// Connect authenticates with MCP using the provided credentials. credentials
+// may be a string API key, a map[string]string{"api_key": "..."}, or an
+// AuthProvider for callers that want full control over the auth flow; if nil,
+// the AuthProvider configured on Config is used.
 func (c *Client) Connect(ctx context.Context, credentials interface{}) (*AuthResult, error) {
+	provider, err := c.resolveAuthProvider(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := provider.Authenticate(ctx, c.httpClient, c.config.BaseURL)
+	if err != nil {
+		return nil, &Error{Code: CodeUnauthenticated, Message: "connect failed", Cause: err}
+	}
+
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.config.AuthProvider = provider
+	c.authToken = result.Token
+	c.authExpiry = result.ExpiresAt
+	c.streamTransport = normalizeStreamTransport(result.StreamTransport)
+	c.mu.Unlock()
+
+	c.startRenewer(provider)
 
-	// Handle different credential types
-	var apiKey string
+	return result, nil
+}
+
+// resolveAuthProvider turns the credentials argument passed to Connect (or
+// the AuthProvider configured on Config, if credentials is nil) into a
+// concrete AuthProvider.
+func (c *Client) resolveAuthProvider(credentials interface{}) (AuthProvider, error) {
 	switch creds := credentials.(type) {
+	case nil:
+		if c.config.AuthProvider != nil {
+			return c.config.AuthProvider, nil
+		}
+		if c.config.APIKey != "" {
+			return &APIKeyAuthProvider{APIKey: c.config.APIKey}, nil
+		}
+		return nil, &Error{Code: CodeBadInput, Message: "no credentials provided and no AuthProvider configured"}
+	case AuthProvider:
+		return creds, nil
 	case string:
-		apiKey = creds
+		return &APIKeyAuthProvider{APIKey: creds}, nil
 	case map[string]string:
 		if key, ok := creds["api_key"]; ok {
-			apiKey = key
-		} else {
-			return nil, errors.New("invalid credentials format")
+			return &APIKeyAuthProvider{APIKey: key}, nil
 		}
+		return nil, &Error{Code: CodeBadInput, Message: "invalid credentials format"}
 	default:
-		return nil, errors.New("credentials must be string or map")
+		return nil, &Error{Code: CodeBadInput, Message: "credentials must be a string, map[string]string, or AuthProvider"}
 	}
-
-	// Simulate authentication (in real implementation, call API)
-	c.authToken = fmt.Sprintf("go-token-%s", apiKey[:8])
-	c.authExpiry = time.Now().Add(time.Hour)
-
-	return &AuthResult{
-		Token:     c.authToken,
-		ExpiresAt: c.authExpiry,
-	}, nil
 }
 
 // ConnectService connects to a specific service
@@ -125,7 +197,7 @@ func (c *Client) ConnectService(ctx context.Context, serviceID string) (*Service
 			return nil, err
 		}
 		if !result.Success {
-			return nil, errors.New(result.Message)
+			return nil, &Error{Code: CodeInternal, Message: result.Message}
 		}
 	}
 
@@ -141,49 +213,78 @@ func (c *Client) ConnectService(ctx context.Context, serviceID string) (*Service
 	return proxy, nil
 }
 
-// ListServices returns a list of available services
+// ListServices returns the available services, consulting the client's
+// ServiceCatalog cache instead of calling the API on every invocation, and
+// applying the category/tag/installed filters against the cached snapshot.
 func (c *Client) ListServices(ctx context.Context, filters map[string]interface{}) ([]Service, error) {
 	if err := c.checkAuth(); err != nil {
 		return nil, err
 	}
 
-	// In real implementation, call API
-	// For now, return mock data
-	return []Service{
-		{
-			ID:          "postgres-mcp",
-			Name:        "PostgreSQL MCP",
-			Description: "PostgreSQL database service",
-			Version:     "14.0",
-			Category:    "database",
-			Tags:        []string{"sql", "database", "postgres"},
-			Status:      "available",
-			Installed:   false,
-		},
-	}, nil
+	services, err := c.catalog.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterServices(services, filters), nil
+}
+
+// WatchServices subscribes to Added/Updated/Removed events as the service
+// catalog changes, instead of requiring callers to poll ListServices.
+func (c *Client) WatchServices(ctx context.Context) (<-chan CatalogEvent, error) {
+	if err := c.checkAuth(); err != nil {
+		return nil, err
+	}
+	return c.catalog.Watch(ctx)
 }
 
-// GetService returns detailed information about a service
+// GetService returns detailed information about a service, consulting the
+// ServiceCatalog cache first.
 func (c *Client) GetService(ctx context.Context, serviceID string) (*Service, error) {
 	if err := c.checkAuth(); err != nil {
 		return nil, err
 	}
 
-	// In real implementation, call API
+	service, ok, err := c.catalog.Get(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		fetched, err := c.fetchService(ctx, serviceID)
+		if err != nil {
+			return nil, err
+		}
+		service = *fetched
+		c.catalog.Upsert(service)
+	}
+
 	c.mu.RLock()
-	_, installed := c.services[serviceID]
+	_, connected := c.services[serviceID]
 	c.mu.RUnlock()
+	if connected {
+		service.Installed = true
+	}
 
-	return &Service{
-		ID:          serviceID,
-		Name:        fmt.Sprintf("%s Service", serviceID),
-		Description: fmt.Sprintf("Description for %s", serviceID),
-		Version:     "1.0.0",
-		Category:    "custom",
-		Tags:        []string{},
-		Status:      "available",
-		Installed:   installed,
-	}, nil
+	return &service, nil
+}
+
+// fetchServices calls the platform API directly, bypassing the cache; it
+// is the ServiceCatalog's refresh function.
+func (c *Client) fetchServices(ctx context.Context) ([]Service, error) {
+	var services []Service
+	if err := c.doJSON(ctx, http.MethodGet, "/services", nil, &services); err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
+// fetchService calls the platform API directly for a single service,
+// bypassing the cache.
+func (c *Client) fetchService(ctx context.Context, serviceID string) (*Service, error) {
+	var service Service
+	if err := c.doJSON(ctx, http.MethodGet, "/services/"+serviceID, nil, &service); err != nil {
+		return nil, err
+	}
+	return &service, nil
 }
 
 // InstallService installs a service
@@ -192,11 +293,16 @@ func (c *Client) InstallService(ctx context.Context, serviceID string, config ma
 		return nil, err
 	}
 
-	// In real implementation, call API
-	return &InstallResult{
-		Success: true,
-		Message: fmt.Sprintf("Service %s installed successfully", serviceID),
-	}, nil
+	var result InstallResult
+	if err := c.doJSON(ctx, http.MethodPost, "/services/"+serviceID+"/install", config, &result); err != nil {
+		return nil, err
+	}
+	if result.Success {
+		if svc, err := c.fetchService(ctx, serviceID); err == nil {
+			c.catalog.Upsert(*svc)
+		}
+	}
+	return &result, nil
 }
 
 // UninstallService uninstalls a service
@@ -205,14 +311,22 @@ func (c *Client) UninstallService(ctx context.Context, serviceID string) (*Insta
 		return nil, err
 	}
 
+	var result InstallResult
+	if err := c.doJSON(ctx, http.MethodPost, "/services/"+serviceID+"/uninstall", nil, &result); err != nil {
+		return nil, err
+	}
+
 	c.mu.Lock()
 	delete(c.services, serviceID)
 	c.mu.Unlock()
 
-	return &InstallResult{
-		Success: true,
-		Message: fmt.Sprintf("Service %s uninstalled successfully", serviceID),
-	}, nil
+	if result.Success {
+		if svc, err := c.fetchService(ctx, serviceID); err == nil {
+			c.catalog.Upsert(*svc)
+		}
+	}
+
+	return &result, nil
 }
 
 // GetHealth returns health status
@@ -221,43 +335,35 @@ func (c *Client) GetHealth(ctx context.Context, serviceID string) (*HealthStatus
 		return nil, err
 	}
 
+	path := "/health"
 	if serviceID != "" {
-		c.mu.RLock()
-		_, connected := c.services[serviceID]
-		c.mu.RUnlock()
-
-		return &HealthStatus{
-			Status: "healthy",
-			Details: map[string]interface{}{
-				"service_id":   serviceID,
-				"connected":    connected,
-				"last_checked": time.Now().Format(time.RFC3339),
-			},
-		}, nil
+		path = "/services/" + serviceID + "/health"
 	}
 
-	// Platform health
-	c.mu.RLock()
-	serviceCount := len(c.services)
-	c.mu.RUnlock()
-
-	return &HealthStatus{
-		Status: "healthy",
-		Details: map[string]interface{}{
-			"authentication":     "valid",
-			"installed_services": serviceCount,
-			"timestamp":         time.Now().Format(time.RFC3339),
-		},
-	}, nil
+	var status HealthStatus
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
 }
 
-// checkAuth verifies authentication is valid
+// checkAuth verifies authentication is valid. If a background renewal is
+// in flight, it waits for that renewal to finish before deciding, so
+// callers don't race against a token that is about to be replaced.
 func (c *Client) checkAuth() error {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.renewing {
+		c.renewCond.Wait()
+	}
+
+	if c.renewBehavior == RenewSurfaceErrors && c.lastRenewErr != nil {
+		return c.lastRenewErr
+	}
 
 	if c.authToken == "" || time.Now().After(c.authExpiry) {
-		return errors.New("authentication required")
+		return ErrUnauthenticated
 	}
 	return nil
 }
@@ -268,26 +374,103 @@ type ServiceProxy struct {
 	serviceID string
 }
 
+// callOptions holds the effective per-call proxy routing decision built up
+// by CallOption values passed to ServiceProxy.Call.
+type callOptions struct {
+	proxy       string
+	proxySet    bool
+	proxyBypass bool
+}
+
+// CallOption customizes a single ServiceProxy.Call invocation.
+type CallOption func(*callOptions)
+
+// WithProxy routes this call through addr instead of the client's
+// configured Config.Proxy (or per-tenant override), regardless of what the
+// client is otherwise configured to do.
+func WithProxy(addr string) CallOption {
+	return func(o *callOptions) {
+		o.proxy = addr
+		o.proxySet = true
+	}
+}
+
+// WithoutProxy bypasses any configured proxy for this call, routing
+// directly to the client's BaseURL.
+func WithoutProxy() CallOption {
+	return func(o *callOptions) {
+		o.proxyBypass = true
+	}
+}
+
 // Call invokes a method on the service
-func (sp *ServiceProxy) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+func (sp *ServiceProxy) Call(ctx context.Context, method string, params interface{}, opts ...CallOption) (interface{}, error) {
 	if err := sp.client.checkAuth(); err != nil {
 		return nil, err
 	}
 
-	// In real implementation, call service
-	result := map[string]interface{}{
-		"success":    true,
-		"service_id": sp.serviceID,
-		"method":     method,
-		"params":     params,
-		"result":     fmt.Sprintf("Response from %s.%s", sp.serviceID, method),
-		"timestamp":  time.Now().Format(time.RFC3339),
+	var resolved callOptions
+	for _, opt := range opts {
+		opt(&resolved)
 	}
 
-	return result, nil
+	baseURL := sp.client.config.BaseURL
+	if target := sp.resolveProxy(resolved); target != "" {
+		baseURL = target
+	}
+
+	var envelope callEnvelope
+	path := "/services/" + sp.serviceID + "/call/" + method
+	if err := sp.client.doJSONAt(ctx, baseURL, http.MethodPost, path, params, &envelope); err != nil {
+		return nil, err
+	}
+	if !envelope.Success && envelope.Error != nil {
+		return nil, &Error{
+			Code:    codeFromString(envelope.Error.Code),
+			Message: envelope.Error.Message,
+		}
+	}
+	return envelope.Result, nil
+}
+
+// callEnvelope is the response shape for a service method invocation,
+// letting the server report a method-level failure (e.g. the method
+// itself errored) distinctly from a transport-level one (e.g. a 5xx from
+// the gateway), which doJSONAt already turns into an *Error.
+type callEnvelope struct {
+	Success bool         `json:"success"`
+	Result  interface{}  `json:"result"`
+	Error   *remoteError `json:"error,omitempty"`
+}
+
+// remoteError is the wire representation of a method-level failure; Code
+// is one of the Code.String() names.
+type remoteError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// resolveProxy determines the gateway address, if any, that this call
+// should route through: an explicit per-call override wins, then an
+// explicit bypass, then a per-tenant override, then the client-wide Proxy.
+func (sp *ServiceProxy) resolveProxy(opts callOptions) string {
+	if opts.proxySet {
+		return opts.proxy
+	}
+	if opts.proxyBypass {
+		return ""
+	}
+
+	cfg := sp.client.config
+	if cfg.TenantID != "" && cfg.TenantProxies != nil {
+		if addr, ok := cfg.TenantProxies[cfg.TenantID]; ok && addr != "" {
+			return addr
+		}
+	}
+	return cfg.Proxy
 }
 
 // GetHealth returns the health status of this service
 func (sp *ServiceProxy) GetHealth(ctx context.Context) (*HealthStatus, error) {
 	return sp.client.GetHealth(ctx, sp.serviceID)
-}
\ No newline at end of file
+}
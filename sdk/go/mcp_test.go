@@ -0,0 +1,56 @@
+package mcp
+
+import "testing"
+
+func TestResolveProxyPrecedence(t *testing.T) {
+	cases := []struct {
+		name     string
+		cfg      Config
+		tenantID string
+		opts     callOptions
+		want     string
+	}{
+		{
+			name: "WithProxy wins over everything",
+			cfg:  Config{Proxy: "global", TenantProxies: map[string]string{"t1": "tenant"}},
+			opts: callOptions{proxySet: true, proxy: "override"},
+			want: "override",
+		},
+		{
+			name: "WithoutProxy bypasses tenant and global proxy",
+			cfg:  Config{Proxy: "global", TenantProxies: map[string]string{"t1": "tenant"}},
+			opts: callOptions{proxyBypass: true},
+			want: "",
+		},
+		{
+			name:     "tenant override wins over global Proxy",
+			cfg:      Config{Proxy: "global", TenantProxies: map[string]string{"t1": "tenant"}},
+			tenantID: "t1",
+			want:     "tenant",
+		},
+		{
+			name:     "falls back to global Proxy when tenant has no override",
+			cfg:      Config{Proxy: "global", TenantProxies: map[string]string{"other": "tenant"}},
+			tenantID: "t1",
+			want:     "global",
+		},
+		{
+			name: "empty when nothing configured",
+			cfg:  Config{},
+			want: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := c.cfg
+			cfg.TenantID = c.tenantID
+			client := NewClient(cfg)
+			sp := &ServiceProxy{client: client, serviceID: "svc"}
+
+			if got := sp.resolveProxy(c.opts); got != c.want {
+				t.Errorf("resolveProxy() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
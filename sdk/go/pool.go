@@ -0,0 +1,255 @@
+package mcp
+
+import (
+	"context"
+	"expvar"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TenantPoolConfig configures a TenantPool.
+type TenantPoolConfig struct {
+	// BaseConfig supplies the defaults (BaseURL, Timeout, RetryAttempts,
+	// Proxy, CatalogTTL, RenewBehavior, ...) every tenant's Client is
+	// built from; TenantID and AuthProvider are overridden per tenant.
+	BaseConfig Config
+
+	// AuthProviderFactory builds the AuthProvider used to authenticate a
+	// given tenant's Client. Most deployments share one set of platform
+	// credentials across tenants and just tag requests with TenantID, so
+	// the factory is free to ignore its argument and return the same
+	// provider every time.
+	AuthProviderFactory func(tenantID string) AuthProvider
+
+	// RateLimitPerSecond caps how many requests per second Allow grants
+	// a single tenant. Zero means unlimited.
+	RateLimitPerSecond float64
+
+	// MetricsVarName, if set, publishes this pool's per-tenant metrics
+	// under that name via expvar.
+	MetricsVarName string
+}
+
+// TenantPool manages one authenticated Client per tenant, reusing a single
+// underlying http.Transport for connection pooling across all of them and
+// applying a per-tenant rate limit, so a gateway fronting many tenants
+// doesn't pay for a new TCP/TLS handshake or auth round trip per request.
+type TenantPool struct {
+	config    TenantPoolConfig
+	transport *http.Transport
+
+	mu           sync.RWMutex
+	clients      map[string]*Client
+	initializing map[string]*tenantInit
+	limiters     map[string]*tokenBucket
+	metrics      map[string]*TenantMetrics
+}
+
+// tenantInit coordinates concurrent For calls for a tenant that hasn't
+// finished authenticating yet: the first caller runs the connect, and any
+// others for the same tenant block on once.Do rather than repeating it,
+// while callers for other tenants are never blocked at all since the pool
+// lock isn't held across the network round trip.
+type tenantInit struct {
+	once   sync.Once
+	client *Client
+	err    error
+}
+
+// NewTenantPool creates a TenantPool. The returned pool owns a shared
+// http.Transport handed to every Client it creates.
+func NewTenantPool(config TenantPoolConfig) *TenantPool {
+	p := &TenantPool{
+		config:       config,
+		transport:    &http.Transport{MaxIdleConnsPerHost: 64},
+		clients:      make(map[string]*Client),
+		initializing: make(map[string]*tenantInit),
+		limiters:     make(map[string]*tokenBucket),
+		metrics:      make(map[string]*TenantMetrics),
+	}
+
+	if config.MetricsVarName != "" {
+		expvar.Publish(config.MetricsVarName, expvar.Func(p.snapshotMetrics))
+	}
+
+	return p
+}
+
+// For returns the authenticated Client for tenantID, lazily creating and
+// connecting one on first use. The pool-wide lock is never held across the
+// Connect round trip: a cold or slow-to-authenticate tenant only blocks
+// other callers for that same tenant, not the whole pool.
+func (p *TenantPool) For(ctx context.Context, tenantID string) (*Client, error) {
+	p.mu.RLock()
+	client, ok := p.clients[tenantID]
+	p.mu.RUnlock()
+	if ok {
+		return client, nil
+	}
+
+	p.mu.Lock()
+	if client, ok := p.clients[tenantID]; ok {
+		p.mu.Unlock()
+		return client, nil
+	}
+	init, ok := p.initializing[tenantID]
+	if !ok {
+		init = &tenantInit{}
+		p.initializing[tenantID] = init
+	}
+	p.mu.Unlock()
+
+	init.once.Do(func() {
+		config := p.config.BaseConfig
+		config.TenantID = tenantID
+		if p.config.AuthProviderFactory != nil {
+			config.AuthProvider = p.config.AuthProviderFactory(tenantID)
+		}
+
+		client := NewClient(config)
+		client.httpClient.Transport = p.transport
+
+		if _, err := client.Connect(ctx, nil); err != nil {
+			init.err = err
+			return
+		}
+		init.client = client
+
+		p.mu.Lock()
+		p.clients[tenantID] = client
+		delete(p.initializing, tenantID)
+		p.mu.Unlock()
+	})
+
+	if init.err != nil {
+		// Let a later call retry instead of caching the failure forever.
+		p.mu.Lock()
+		delete(p.initializing, tenantID)
+		p.mu.Unlock()
+		return nil, init.err
+	}
+
+	return init.client, nil
+}
+
+// Allow reports whether tenantID is within its rate limit, consuming one
+// unit of quota if so. It always returns true when RateLimitPerSecond is
+// zero.
+func (p *TenantPool) Allow(tenantID string) bool {
+	if p.config.RateLimitPerSecond <= 0 {
+		return true
+	}
+
+	p.mu.Lock()
+	limiter, ok := p.limiters[tenantID]
+	if !ok {
+		limiter = newTokenBucket(p.config.RateLimitPerSecond)
+		p.limiters[tenantID] = limiter
+	}
+	p.mu.Unlock()
+
+	return limiter.Allow()
+}
+
+// TenantMetrics tracks usage for a single tenant. All fields are updated
+// atomically and safe for concurrent use.
+type TenantMetrics struct {
+	Requests int64
+	Errors   int64
+	Installs int64
+}
+
+// RecordRequest increments tenantID's request count. Callers should invoke
+// this once per request dispatched to the tenant's Client.
+func (p *TenantPool) RecordRequest(tenantID string) {
+	atomic.AddInt64(&p.metricsFor(tenantID).Requests, 1)
+}
+
+// RecordError increments tenantID's error count. Safe to call even if the
+// tenant never successfully authenticated.
+func (p *TenantPool) RecordError(tenantID string) {
+	atomic.AddInt64(&p.metricsFor(tenantID).Errors, 1)
+}
+
+// RecordInstall increments tenantID's install count.
+func (p *TenantPool) RecordInstall(tenantID string) {
+	atomic.AddInt64(&p.metricsFor(tenantID).Installs, 1)
+}
+
+// Metrics returns a snapshot of every tenant's counters.
+func (p *TenantPool) Metrics() map[string]TenantMetrics {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	snapshot := make(map[string]TenantMetrics, len(p.metrics))
+	for tenantID, m := range p.metrics {
+		snapshot[tenantID] = TenantMetrics{
+			Requests: atomic.LoadInt64(&m.Requests),
+			Errors:   atomic.LoadInt64(&m.Errors),
+			Installs: atomic.LoadInt64(&m.Installs),
+		}
+	}
+	return snapshot
+}
+
+// metricsFor returns tenantID's metrics, creating an entry on first sight.
+// This runs independently of Connect succeeding, so a tenant whose
+// credentials never work still accumulates a visible error count instead
+// of silently dropping every RecordError call.
+func (p *TenantPool) metricsFor(tenantID string) *TenantMetrics {
+	p.mu.RLock()
+	m, ok := p.metrics[tenantID]
+	p.mu.RUnlock()
+	if ok {
+		return m
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if m, ok := p.metrics[tenantID]; ok {
+		return m
+	}
+	m = &TenantMetrics{}
+	p.metrics[tenantID] = m
+	return m
+}
+
+// snapshotMetrics adapts Metrics to the shape expvar.Func expects.
+func (p *TenantPool) snapshotMetrics() interface{} {
+	return p.Metrics()
+}
+
+// tokenBucket is a minimal per-tenant rate limiter: it refills at rate
+// tokens per second, up to a burst of rate tokens.
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64
+	tokens   float64
+	capacity float64
+	last     time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	return &tokenBucket{rate: rate, tokens: rate, capacity: rate, last: time.Now()}
+}
+
+// Allow reports whether a token is available and, if so, consumes it.
+func (b *tokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
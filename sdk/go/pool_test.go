@@ -0,0 +1,130 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// gatedAuthProvider lets a test observe exactly when Authenticate starts
+// and control when it returns, to reproduce a slow/stuck tenant login.
+type gatedAuthProvider struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (g *gatedAuthProvider) Authenticate(ctx context.Context, httpClient *http.Client, baseURL string) (*AuthResult, error) {
+	close(g.started)
+	select {
+	case <-g.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &AuthResult{Token: "tok", ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
+
+type erroringAuthProvider struct{}
+
+func (erroringAuthProvider) Authenticate(ctx context.Context, httpClient *http.Client, baseURL string) (*AuthResult, error) {
+	return nil, errors.New("boom")
+}
+
+// TestTenantPoolForDoesNotSerializeAcrossTenants reproduces the scenario
+// from the review: one tenant stuck mid-authentication must not block
+// For calls for other, unrelated tenants.
+func TestTenantPoolForDoesNotSerializeAcrossTenants(t *testing.T) {
+	slow := &gatedAuthProvider{started: make(chan struct{}), release: make(chan struct{})}
+	fast := &fakeAuthProvider{}
+
+	pool := NewTenantPool(TenantPoolConfig{
+		AuthProviderFactory: func(tenantID string) AuthProvider {
+			if tenantID == "slow" {
+				return slow
+			}
+			return fast
+		},
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := pool.For(context.Background(), "slow")
+		done <- err
+	}()
+
+	select {
+	case <-slow.started:
+	case <-time.After(time.Second):
+		t.Fatal("slow tenant's Authenticate never started")
+	}
+
+	start := time.Now()
+	if _, err := pool.For(context.Background(), "fast"); err != nil {
+		t.Fatalf("For(fast): %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Fatalf("For(fast) took %v while tenant \"slow\" was mid-authentication; the pool lock is serializing tenants", elapsed)
+	}
+
+	close(slow.release)
+	if err := <-done; err != nil {
+		t.Fatalf("For(slow): %v", err)
+	}
+}
+
+// TestTenantPoolForConcurrentSameTenant checks that concurrent For calls
+// for the same tenant share a single Connect instead of racing to create
+// duplicate clients.
+func TestTenantPoolForConcurrentSameTenant(t *testing.T) {
+	var calls int
+	pool := NewTenantPool(TenantPoolConfig{
+		AuthProviderFactory: func(tenantID string) AuthProvider {
+			calls++
+			return &fakeAuthProvider{}
+		},
+	})
+
+	const n = 8
+	results := make(chan *Client, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			client, err := pool.For(context.Background(), "shared")
+			if err != nil {
+				t.Errorf("For: %v", err)
+			}
+			results <- client
+		}()
+	}
+
+	var first *Client
+	for i := 0; i < n; i++ {
+		client := <-results
+		if first == nil {
+			first = client
+		} else if client != first {
+			t.Fatal("concurrent For calls for the same tenant returned different clients")
+		}
+	}
+}
+
+// TestTenantPoolRecordErrorWithoutSuccessfulConnect checks that a tenant
+// whose credentials never work still shows up in metrics, rather than
+// RecordError silently no-oping because For never reached the success path.
+func TestTenantPoolRecordErrorWithoutSuccessfulConnect(t *testing.T) {
+	pool := NewTenantPool(TenantPoolConfig{
+		AuthProviderFactory: func(tenantID string) AuthProvider {
+			return erroringAuthProvider{}
+		},
+	})
+
+	if _, err := pool.For(context.Background(), "bad"); err == nil {
+		t.Fatal("expected For to fail for a tenant whose auth always errors")
+	}
+	pool.RecordError("bad")
+
+	metrics := pool.Metrics()
+	if metrics["bad"].Errors != 1 {
+		t.Fatalf("metrics[bad].Errors = %d, want 1", metrics["bad"].Errors)
+	}
+}
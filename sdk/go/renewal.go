@@ -0,0 +1,114 @@
+package mcp
+
+import (
+	"context"
+	"time"
+)
+
+// RenewBehavior controls how the background renewer reacts when a renewal
+// attempt fails.
+type RenewBehavior int
+
+const (
+	// RenewIgnoreErrors keeps retrying renewal in the background and only
+	// surfaces a problem once the current token actually expires. This is
+	// the default.
+	RenewIgnoreErrors RenewBehavior = iota
+	// RenewSurfaceErrors causes checkAuth to fail immediately, even before
+	// expiry, once a renewal attempt has failed.
+	RenewSurfaceErrors
+)
+
+// renewRetryInterval is how long the renewer waits before trying again
+// after a failed renewal attempt.
+const renewRetryInterval = 30 * time.Second
+
+// startRenewer launches (or restarts) the background goroutine that
+// re-authenticates using provider shortly before the current token expires.
+// Any previously running renewer is stopped first.
+func (c *Client) startRenewer(provider AuthProvider) {
+	c.mu.Lock()
+	if c.renewCancel != nil {
+		c.renewCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c.renewCancel = cancel
+	c.renewBehavior = c.config.RenewBehavior
+	c.mu.Unlock()
+
+	c.renewWG.Add(1)
+	go c.renewLoop(ctx, provider)
+}
+
+// renewLoop re-authenticates at roughly 2/3 of the token's remaining
+// lifetime, following the approach of Vault's LifetimeWatcher, and keeps
+// retrying on failure until the context is cancelled via Shutdown.
+func (c *Client) renewLoop(ctx context.Context, provider AuthProvider) {
+	defer c.renewWG.Done()
+
+	for {
+		c.mu.RLock()
+		delay := time.Until(c.authExpiry) * 2 / 3
+		c.mu.RUnlock()
+		if delay < 0 {
+			delay = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		c.mu.Lock()
+		c.renewing = true
+		c.mu.Unlock()
+
+		result, err := provider.Authenticate(ctx, c.httpClient, c.config.BaseURL)
+
+		c.mu.Lock()
+		if err != nil {
+			c.lastRenewErr = &Error{Code: CodeUnauthenticated, Message: "token renewal failed", Cause: err}
+		} else {
+			c.authToken = result.Token
+			c.authExpiry = result.ExpiresAt
+			c.lastRenewErr = nil
+		}
+		c.renewing = false
+		c.renewCond.Broadcast()
+		c.mu.Unlock()
+
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(renewRetryInterval):
+			}
+		}
+	}
+}
+
+// Shutdown stops the background token renewer, if one is running, and waits
+// for it to exit or for ctx to be done, whichever comes first.
+func (c *Client) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	cancel := c.renewCancel
+	c.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		c.renewWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
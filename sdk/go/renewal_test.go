@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAuthProvider lets tests control how long each Authenticate call
+// takes and how long the token it returns lives, without making a real
+// HTTP round trip.
+type fakeAuthProvider struct {
+	mu     sync.Mutex
+	calls  int
+	delays []time.Duration
+	ttls   []time.Duration
+}
+
+func (f *fakeAuthProvider) Authenticate(ctx context.Context, httpClient *http.Client, baseURL string) (*AuthResult, error) {
+	f.mu.Lock()
+	i := f.calls
+	f.calls++
+	f.mu.Unlock()
+
+	if i < len(f.delays) && f.delays[i] > 0 {
+		select {
+		case <-time.After(f.delays[i]):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	ttl := 50 * time.Millisecond
+	if i < len(f.ttls) {
+		ttl = f.ttls[i]
+	}
+
+	return &AuthResult{Token: fmt.Sprintf("token-%d", i), ExpiresAt: time.Now().Add(ttl)}, nil
+}
+
+func TestClientRenewsTokenBeforeExpiry(t *testing.T) {
+	provider := &fakeAuthProvider{ttls: []time.Duration{150 * time.Millisecond, time.Hour}}
+	client := NewClient(Config{AuthProvider: provider})
+
+	if _, err := client.Connect(context.Background(), nil); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		client.mu.RLock()
+		token := client.authToken
+		client.mu.RUnlock()
+		if token == "token-1" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("token was not renewed before expiry")
+}
+
+func TestCheckAuthWaitsForInFlightRenewal(t *testing.T) {
+	provider := &fakeAuthProvider{
+		delays: []time.Duration{0, 150 * time.Millisecond},
+		ttls:   []time.Duration{100 * time.Millisecond, time.Hour},
+	}
+	client := NewClient(Config{AuthProvider: provider})
+
+	if _, err := client.Connect(context.Background(), nil); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	// Give the renewer time to notice expiry is near and start its slow
+	// second Authenticate call.
+	time.Sleep(80 * time.Millisecond)
+
+	start := time.Now()
+	if err := client.checkAuth(); err != nil {
+		t.Fatalf("checkAuth: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("checkAuth returned after %v, expected it to block for the in-flight renewal", elapsed)
+	}
+
+	client.mu.RLock()
+	token := client.authToken
+	client.mu.RUnlock()
+	if token != "token-1" {
+		t.Fatalf("authToken = %q, want the renewed token", token)
+	}
+}
+
+func TestShutdownStopsRenewer(t *testing.T) {
+	provider := &fakeAuthProvider{ttls: []time.Duration{50 * time.Millisecond}}
+	client := NewClient(Config{AuthProvider: provider})
+
+	if _, err := client.Connect(context.Background(), nil); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	provider.mu.Lock()
+	callsAtShutdown := provider.calls
+	provider.mu.Unlock()
+	time.Sleep(150 * time.Millisecond)
+	provider.mu.Lock()
+	calls := provider.calls
+	provider.mu.Unlock()
+	if calls != callsAtShutdown {
+		t.Fatalf("renewer kept running after Shutdown: calls went from %d to %d", callsAtShutdown, calls)
+	}
+}
@@ -0,0 +1,257 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Event is a single message delivered over a Stream, numbered in the order
+// the server emitted it so consumers can detect gaps or reordering.
+type Event struct {
+	Seq  int64           `json:"seq"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Transports a server can negotiate for ServiceProxy.Stream, returned as
+// AuthResult.StreamTransport. WebSocket is a recognized value but not yet
+// implemented by this client: there is no WebSocket implementation in the
+// standard library and this package takes no external dependencies, so a
+// server that negotiates it gets ErrUnimplemented from Stream rather than
+// a silent fallback to a transport it didn't ask for.
+const (
+	streamTransportSSE     = "sse"
+	streamTransportChunked = "chunked"
+	streamTransportWS      = "websocket"
+)
+
+// normalizeStreamTransport defaults an unset or unrecognized negotiated
+// transport to SSE, the transport this client has always spoken.
+func normalizeStreamTransport(transport string) string {
+	switch transport {
+	case streamTransportChunked, streamTransportWS:
+		return transport
+	default:
+		return streamTransportSSE
+	}
+}
+
+// Stream represents a long-running or subscription-style method
+// invocation. Events arrive on the channel returned by Events until the
+// context passed to ServiceProxy.Stream is cancelled or the server closes
+// the connection; callers should then check Err to distinguish a clean EOF
+// from a transport failure.
+type Stream struct {
+	events chan Event
+	cancel context.CancelFunc
+	closed chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+// Events returns the channel Stream delivers events on. It is closed once
+// the stream ends, whether cleanly or with an error.
+func (s *Stream) Events() <-chan Event {
+	return s.events
+}
+
+// Err returns the terminal error that ended the stream, if any. It is only
+// meaningful after Events has been closed; it returns nil for a clean EOF.
+func (s *Stream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close cancels the stream and waits for its reader goroutine to exit.
+func (s *Stream) Close() error {
+	s.cancel()
+	<-s.closed
+	return nil
+}
+
+func (s *Stream) setErr(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+// Stream invokes a long-running or subscription-style method on the
+// service and streams its results back as Events, using whichever
+// transport the server negotiated for this session at Connect time (see
+// AuthResult.StreamTransport): chunked HTTP or SSE. WebSocket is
+// recognized but returns ErrUnimplemented; see the streamTransport*
+// constants.
+func (sp *ServiceProxy) Stream(ctx context.Context, method string, params interface{}) (*Stream, error) {
+	if err := sp.client.checkAuth(); err != nil {
+		return nil, err
+	}
+
+	sp.client.mu.RLock()
+	transport := sp.client.streamTransport
+	token := sp.client.authToken
+	tenantID := sp.client.config.TenantID
+	sp.client.mu.RUnlock()
+	if transport == "" {
+		transport = streamTransportSSE
+	}
+	if transport == streamTransportWS {
+		return nil, &Error{Code: CodeUnimplemented, Message: "server negotiated websocket streaming, which this client does not yet implement"}
+	}
+
+	reqBody, err := json.Marshal(params)
+	if err != nil {
+		return nil, &Error{Code: CodeBadInput, Message: "encoding stream params", Cause: err}
+	}
+
+	baseURL := sp.client.config.BaseURL
+	if target := sp.resolveProxy(callOptions{}); target != "" {
+		baseURL = target
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+
+	path := "/services/" + sp.serviceID + "/stream/" + method
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodPost, baseURL+path, bytes.NewReader(reqBody))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if transport == streamTransportChunked {
+		req.Header.Set("Accept", "application/x-ndjson")
+	} else {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if tenantID != "" {
+		req.Header.Set("X-Tenant-ID", tenantID)
+	}
+
+	resp, err := sp.client.httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		cancel()
+		return nil, errFromResponse(resp.StatusCode, body, resp.Header)
+	}
+
+	s := &Stream{
+		events: make(chan Event, 16),
+		cancel: cancel,
+		closed: make(chan struct{}),
+	}
+	if transport == streamTransportChunked {
+		go s.readChunked(streamCtx, resp)
+	} else {
+		go s.readSSE(streamCtx, resp)
+	}
+	return s, nil
+}
+
+// readSSE decodes the text/event-stream body into Events, assigning each a
+// monotonically increasing sequence number, until ctx is cancelled or the
+// server closes the connection.
+func (s *Stream) readSSE(ctx context.Context, resp *http.Response) {
+	defer close(s.events)
+	defer close(s.closed)
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var seq int64
+	var dataLines []string
+	var eventType string
+
+	flush := func() {
+		if len(dataLines) == 0 {
+			return
+		}
+		seq++
+		data := strings.Join(dataLines, "\n")
+		select {
+		case s.events <- Event{Seq: seq, Type: eventType, Data: json.RawMessage(data)}:
+		case <-ctx.Done():
+		}
+		dataLines = nil
+		eventType = ""
+	}
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return
+		}
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "data:"):
+			dataLines = append(dataLines, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case strings.HasPrefix(line, "event:"):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		s.setErr(err)
+		return
+	}
+	if err := ctx.Err(); err != nil && err != context.Canceled {
+		s.setErr(err)
+	}
+}
+
+// readChunked decodes a newline-delimited-JSON body (application/x-ndjson)
+// into Events, one per line, assigning each a monotonically increasing
+// sequence number, until ctx is cancelled or the server closes the
+// connection.
+func (s *Stream) readChunked(ctx context.Context, resp *http.Response) {
+	defer close(s.events)
+	defer close(s.closed)
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var seq int64
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		seq++
+		data := make(json.RawMessage, len(line))
+		copy(data, line)
+		select {
+		case s.events <- Event{Seq: seq, Data: data}:
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		s.setErr(err)
+		return
+	}
+	if err := ctx.Err(); err != nil && err != context.Canceled {
+		s.setErr(err)
+	}
+}
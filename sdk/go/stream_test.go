@@ -0,0 +1,137 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newStreamTestProxy builds a ServiceProxy whose Client is already
+// authenticated (via fakeAuthProvider) and negotiated to streamTransport,
+// pointed at srv.
+func newStreamTestProxy(t *testing.T, srv *httptest.Server, streamTransport string) *ServiceProxy {
+	t.Helper()
+	provider := &fakeAuthProvider{ttls: []time.Duration{time.Hour}}
+	client := NewClient(Config{BaseURL: srv.URL, AuthProvider: provider})
+	client.mu.Lock()
+	client.authToken = "tok"
+	client.authExpiry = time.Now().Add(time.Hour)
+	client.streamTransport = streamTransport
+	client.mu.Unlock()
+	return &ServiceProxy{client: client, serviceID: "svc"}
+}
+
+func TestStreamReadsSSEEventsInOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "text/event-stream" {
+			t.Errorf("Accept = %q, want text/event-stream", got)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"n\":1}\n\n")
+		fmt.Fprint(w, "data: {\"n\":2}\n\n")
+	}))
+	defer srv.Close()
+
+	sp := newStreamTestProxy(t, srv, streamTransportSSE)
+	stream, err := sp.Stream(context.Background(), "watch", nil)
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+
+	var seqs []int64
+	for ev := range stream.Events() {
+		seqs = append(seqs, ev.Seq)
+	}
+	if len(seqs) != 2 || seqs[0] != 1 || seqs[1] != 2 {
+		t.Fatalf("seqs = %v, want [1 2]", seqs)
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil after a clean EOF", err)
+	}
+}
+
+func TestStreamReadsChunkedNDJSONEventsInOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "application/x-ndjson" {
+			t.Errorf("Accept = %q, want application/x-ndjson", got)
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprint(w, "{\"n\":1}\n")
+		fmt.Fprint(w, "{\"n\":2}\n")
+	}))
+	defer srv.Close()
+
+	sp := newStreamTestProxy(t, srv, streamTransportChunked)
+	stream, err := sp.Stream(context.Background(), "watch", nil)
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+
+	var seqs []int64
+	for ev := range stream.Events() {
+		seqs = append(seqs, ev.Seq)
+	}
+	if len(seqs) != 2 || seqs[0] != 1 || seqs[1] != 2 {
+		t.Fatalf("seqs = %v, want [1 2]", seqs)
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil after a clean EOF", err)
+	}
+}
+
+func TestStreamRejectsWebsocketTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("server should not be contacted when the transport is websocket")
+	}))
+	defer srv.Close()
+
+	sp := newStreamTestProxy(t, srv, streamTransportWS)
+	_, err := sp.Stream(context.Background(), "watch", nil)
+	if !IsCode(err, CodeUnimplemented) {
+		t.Fatalf("err = %v, want CodeUnimplemented", err)
+	}
+}
+
+func TestStreamClosesEventsOnCancel(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"n\":1}\n\n")
+		w.(http.Flusher).Flush()
+		<-block
+	}))
+	defer func() {
+		close(block)
+		srv.Close()
+	}()
+
+	sp := newStreamTestProxy(t, srv, streamTransportSSE)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := sp.Stream(ctx, "watch", nil)
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+
+	select {
+	case ev := <-stream.Events():
+		if ev.Seq != 1 {
+			t.Fatalf("Seq = %d, want 1", ev.Seq)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("did not receive the first event")
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-stream.Events():
+		if ok {
+			t.Fatal("expected Events() to be closed after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Events() did not close after cancel")
+	}
+}
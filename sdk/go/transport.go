@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// doJSON performs an HTTP request against the platform API, making at most
+// config.RetryAttempts attempts in total and retrying transient failures
+// with exponential backoff and jitter. The response body, if any, is
+// decoded into out.
+// A nil out discards the body after checking the status code.
+func (c *Client) doJSON(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	return c.doJSONAt(ctx, c.config.BaseURL, method, path, body, out)
+}
+
+// doJSONAt is like doJSON but targets baseURL instead of c.config.BaseURL,
+// letting callers such as ServiceProxy.Call route through a gateway/proxy
+// address instead of the platform API directly.
+func (c *Client) doJSONAt(ctx context.Context, baseURL, method, path string, body interface{}, out interface{}) error {
+	var reqBody []byte
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request body: %w", err)
+		}
+		reqBody = encoded
+	}
+
+	c.mu.RLock()
+	token := c.authToken
+	c.mu.RUnlock()
+
+	attempts := c.config.RetryAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := sleepBackoff(ctx, attempt); err != nil {
+				return err
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, baseURL+path, bytes.NewReader(reqBody))
+		if err != nil {
+			return err
+		}
+		if reqBody != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if c.config.TenantID != "" {
+			req.Header.Set("X-Tenant-ID", c.config.TenantID)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			continue
+		}
+
+		respBody, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			lastErr = readErr
+			continue
+		}
+
+		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+			lastErr = errFromResponse(resp.StatusCode, respBody, resp.Header)
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return errFromResponse(resp.StatusCode, respBody, resp.Header)
+		}
+
+		if out != nil && len(respBody) > 0 {
+			if err := json.Unmarshal(respBody, out); err != nil {
+				return fmt.Errorf("decoding response: %w", err)
+			}
+		}
+		return nil
+	}
+
+	return lastErr
+}
+
+// sleepBackoff waits for an exponentially increasing, jittered delay
+// before the next retry attempt, returning early if ctx is cancelled.
+func sleepBackoff(ctx context.Context, attempt int) error {
+	base := 100 * time.Millisecond
+	delay := base << uint(attempt-1)
+	if maxDelay := 5 * time.Second; delay > maxDelay {
+		delay = maxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay) / 2))
+	delay = delay/2 + jitter
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSleepBackoffIsBoundedAndJittered(t *testing.T) {
+	start := time.Now()
+	if err := sleepBackoff(context.Background(), 1); err != nil {
+		t.Fatalf("sleepBackoff(1): %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Fatalf("sleepBackoff(1) took %v, want well under the 100ms base delay doubled", elapsed)
+	}
+
+	start = time.Now()
+	if err := sleepBackoff(context.Background(), 10); err != nil {
+		t.Fatalf("sleepBackoff(10): %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second+500*time.Millisecond {
+		t.Fatalf("sleepBackoff(10) took %v, want capped near the 5s max delay", elapsed)
+	}
+}
+
+func TestSleepBackoffReturnsEarlyOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	if err := sleepBackoff(ctx, 10); err == nil {
+		t.Fatal("expected sleepBackoff to return an error for a cancelled context")
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("sleepBackoff with a cancelled context took %v, want near-immediate return", elapsed)
+	}
+}
+
+func TestDoJSONRetriesUntilSuccess(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(Config{BaseURL: srv.URL, RetryAttempts: 5})
+
+	var out struct {
+		OK bool `json:"ok"`
+	}
+	if err := client.doJSON(context.Background(), http.MethodGet, "/x", nil, &out); err != nil {
+		t.Fatalf("doJSON: %v", err)
+	}
+	if !out.OK {
+		t.Fatal("expected decoded response body with ok=true")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("server saw %d attempts, want 3 (two failures then success)", got)
+	}
+}
+
+func TestDoJSONStopsRetryingAfterAttemptsExhausted(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := NewClient(Config{BaseURL: srv.URL, RetryAttempts: 3})
+
+	err := client.doJSON(context.Background(), http.MethodGet, "/x", nil, nil)
+	if err == nil {
+		t.Fatal("expected an error once retries are exhausted")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("server saw %d attempts, want exactly RetryAttempts=3", got)
+	}
+}
+
+func TestDoJSONDoesNotRetryClientErrors(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client := NewClient(Config{BaseURL: srv.URL, RetryAttempts: 5})
+
+	err := client.doJSON(context.Background(), http.MethodGet, "/x", nil, nil)
+	if !IsCode(err, CodeNotFound) {
+		t.Fatalf("doJSON error = %v, want CodeNotFound", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Fatalf("server saw %d attempts, want 1 (4xx should not be retried)", got)
+	}
+}